@@ -6,34 +6,48 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"os"
 	"os/exec"
-	"os/signal"
 	"os/user"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
-
-	"gopkg.in/fsnotify.v1"
 )
 
-var signals = make(chan os.Signal)
-var watcher *fsnotify.Watcher
+// daemonStartTime anchors @reboot and @after plans to this run of the
+// daemon; it is set once, at the top of main.
+var daemonStartTime time.Time
 
-func init() {
-	signal.Notify(signals, syscall.SIGUSR1)
-	var err error
-	watcher, err = fsnotify.NewWatcher()
-	if err != nil {
-		log.Fatal(err)
+// rebootRan tracks which job paths have already fired their @reboot plan
+// during this run, so restarts re-trigger them but rescans within the same
+// run don't.
+var rebootRan = make(map[string]bool)
+
+// connCh carries control-socket connections from the accept loop to the
+// idle select in main/checkJobs.
+var connCh = make(chan net.Conn)
+
+// reloadRequests wakes the idle select after a control command (reload,
+// run, enable, disable) changes something checkJobs should react to
+// immediately.
+var reloadRequests = make(chan struct{}, 1)
+
+func requestReload() {
+	select {
+	case reloadRequests <- struct{}{}:
+	default:
 	}
 }
 
 func main() {
-	defer watcher.Close()
+	if len(os.Args) > 1 {
+		os.Exit(runClient(os.Args[1:]))
+	}
+
+	daemonStartTime = time.Now()
 
 	user, err := user.Current()
 	if err != nil {
@@ -49,18 +63,23 @@ func main() {
 	} else if err != nil {
 		log.Fatal(err)
 	}
-	err = watcher.Add(jobDir)
+
+	listener, err := listenControlSocket(jobDir)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer listener.Close()
+	go acceptControlConns(listener)
+
 	for {
 		hasJob := checkJobs(jobDir)
 		if hasJob {
 			continue
 		} else {
 			select {
-			case <-signals:
-			case <-watcher.Events:
+			case conn := <-connCh:
+				go handleControlConn(conn, jobDir)
+			case <-reloadRequests:
 			}
 			continue
 		}
@@ -77,18 +96,48 @@ type Plan struct {
 	Time    time.Time
 	Comment string
 	State   int
+	Reboot  bool // true for @reboot plans, dedupe via rebootRan instead of Job.Log
+
+	// CatchupSlots holds missed occurrences (from "catchup: true"/"catchup:
+	// N") waiting to be replayed, each tagged with its intended slot time
+	// rather than the wall clock.
+	CatchupSlots []time.Time
+}
+
+// TimeRange is a minute-of-day interval (0-1439), both ends inclusive.
+type TimeRange struct {
+	After  int
+	Before int
 }
 
 type Job struct {
-	Cmd   string
-	Args  []string
-	Path  string
-	Plans []*Plan
-	Log   []time.Time
+	Cmd     string
+	Args    []string
+	Path    string
+	Plans   []*Plan
+	Log     []time.Time
+	ModTime time.Time // job file's mtime, used to anchor "every N ..." recurrence when Log is empty
+
+	// WeeklyRanges holds the "between <days> <time>-<time>" allow-windows,
+	// indexed by time.Weekday. Empty when the job has none, in which case
+	// it fires unconditionally.
+	WeeklyRanges [7][]TimeRange
 }
 
 func (self *Job) Run() {
 	fmt.Printf("Run: %s %v\n", self.Cmd, self.Args)
+	self.logAndExec(time.Now())
+}
+
+// RunAt replays a missed occurrence, logging the slot it was meant to fire
+// at rather than the wall clock, so a later restart still dedupes it via
+// hasLog.
+func (self *Job) RunAt(slot time.Time) {
+	fmt.Printf("Catchup: %s %v -> %s\n", self.Cmd, self.Args, slot.Format(time.RFC822))
+	self.logAndExec(slot)
+}
+
+func (self *Job) logAndExec(slot time.Time) {
 	path := self.Path + ".log"
 	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
 	if err != nil {
@@ -96,8 +145,7 @@ func (self *Job) Run() {
 		return
 	}
 	defer f.Close()
-	now := time.Now()
-	_, err = f.WriteString(fmt.Sprintf("%d-%d-%d %d:%d:%d\n", now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second()))
+	_, err = f.WriteString(fmt.Sprintf("%d-%d-%d %d:%d:%d\n", slot.Year(), slot.Month(), slot.Day(), slot.Hour(), slot.Minute(), slot.Second()))
 	if err != nil {
 		fmt.Printf("Warn: cannot write log file %s, STOP RUNNING\n", path)
 		return
@@ -105,21 +153,32 @@ func (self *Job) Run() {
 	go exec.Command(self.Cmd, self.Args...).Run()
 }
 
+// catchupFire pairs a job with one of its missed catch-up slots.
+type catchupFire struct {
+	job  *Job
+	slot time.Time
+}
+
 func checkJobs(jobDir string) (hasJob bool) {
 	nextPlan := &Plan{Time: time.Date(9999, 1, 1, 0, 0, 0, 0, time.Local)}
 	var nextJob *Job
 	nowJobs := make([]*Job, 0)
+	catchups := make([]catchupFire, 0)
 	filepath.Walk(jobDir, func(path string, info os.FileInfo, err error) error {
 		if info.IsDir() {
 			return nil
 		}
-		if strings.HasSuffix(path, ".log") {
+		if strings.HasSuffix(path, ".log") || strings.HasSuffix(path, ".disabled") || strings.HasSuffix(path, ".sock") {
+			return nil
+		}
+		if isDisabled(path) {
 			return nil
 		}
 		job := &Job{
-			Path:  path,
-			Plans: make([]*Plan, 0),
-			Log:   make([]time.Time, 0),
+			Path:    path,
+			Plans:   make([]*Plan, 0),
+			Log:     make([]time.Time, 0),
+			ModTime: info.ModTime(),
 		}
 		err = job.Parse()
 		if err != nil {
@@ -134,27 +193,48 @@ func checkJobs(jobDir string) (hasJob bool) {
 					nextPlan = p
 				}
 			case NOW:
+				if p.Reboot && rebootRan[job.Path] {
+					continue
+				}
 				nowJobs = append(nowJobs, job)
 			case EXPIRED:
 				fmt.Printf("Expired: %s %s %v %s\n", p.Time.Format(time.RFC822), job.Cmd, job.Args, p.Comment)
 			}
+			for _, slot := range p.CatchupSlots {
+				catchups = append(catchups, catchupFire{job: job, slot: slot})
+			}
 		}
 		return nil
 	})
 
+	for _, c := range catchups {
+		c.job.RunAt(c.slot)
+	}
+
 	for _, job := range nowJobs {
 		job.Run()
+		for _, p := range job.Plans {
+			if p.Reboot {
+				rebootRan[job.Path] = true
+			}
+		}
 	}
 
 	if nextJob != nil {
 		fmt.Printf("Next: %s -> %v -> %s\n", nextPlan.Time.Format(time.RFC822), nextPlan.Time.Sub(time.Now()), nextPlan.Comment)
 		select {
 		case <-time.After(nextPlan.Time.Sub(time.Now())):
-			nextJob.Run()
+			if !nextJob.hasWeeklyRanges() || nextJob.inWeeklyRange(time.Now()) {
+				nextJob.Run()
+				if nextPlan.Reboot {
+					rebootRan[nextJob.Path] = true
+				}
+			}
 			return true
-		case <-signals:
+		case conn := <-connCh:
+			go handleControlConn(conn, jobDir)
 			return true
-		case <-watcher.Events:
+		case <-reloadRequests:
 			return true
 		}
 	}
@@ -197,16 +277,23 @@ func (self *Job) Parse() error {
 	}
 
 	state := parsingPlan
-	for i, line := range lines {
+	havePlan := false
+	for _, line := range lines {
 		switch state {
 		case parsingPlan:
-			if i == 0 || strings.HasPrefix(line, "and ") {
+			if strings.HasPrefix(line, "between ") {
+				err := self.parseWeeklyRange(strings.TrimPrefix(line, "between "))
+				if err != nil {
+					return errors.New("parse between clause")
+				}
+			} else if !havePlan || strings.HasPrefix(line, "and ") {
 				line = strings.TrimPrefix(line, "and ")
 				p, err := self.parsePlan(line)
 				if err != nil {
 					return errors.New("parse datetime")
 				}
 				self.Plans = append(self.Plans, p)
+				havePlan = true
 			} else {
 				self.Cmd = line
 				state = parsingArgs
@@ -215,6 +302,7 @@ func (self *Job) Parse() error {
 			self.Args = append(self.Args, line)
 		}
 	}
+	self.applyWeeklyRanges()
 	return nil
 }
 
@@ -270,14 +358,72 @@ func (self *Job) parsePlan(input string) (*Plan, error) {
 	}
 	comment := strings.Join(comments, " ")
 
+	// "catchup: true" splits into two specs; join them back into one.
+	joined := make([]string, 0, len(specs))
+	for i := 0; i < len(specs); i++ {
+		if strings.EqualFold(specs[i], "catchup:") && i+1 < len(specs) {
+			joined = append(joined, specs[i]+specs[i+1])
+			i++
+			continue
+		}
+		joined = append(joined, specs[i])
+	}
+	specs = joined
+
+	catchupPattern := regexp.MustCompile(`(?i)^catchup:(true|[0-9]+)$`)
+	var catchupLimit int
+	for _, spec := range specs {
+		if m := catchupPattern.FindStringSubmatch(spec); m != nil {
+			if strings.EqualFold(m[1], "true") {
+				catchupLimit = 1
+			} else {
+				n, err := strconv.Atoi(m[1])
+				if err != nil {
+					return nil, errors.New("parse catchup directive")
+				}
+				catchupLimit = n
+			}
+		}
+	}
+	specs = removeMatching(specs, catchupPattern)
+
+	if looksLikeCron(specs) {
+		schedule, err := parseCronSchedule(specs)
+		if err != nil {
+			return nil, err
+		}
+		start, state := self.nextCronRepeat(schedule)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return schedule.Next(t)
+			})
+		}
+		return &Plan{
+			Time:         start,
+			Comment:      comment,
+			State:        state,
+			CatchupSlots: catchupSlots,
+		}, nil
+	}
+
+	if len(specs) > 0 && strings.HasPrefix(specs[0], "@") {
+		return self.parsePredefinedPlan(specs, comment, catchupLimit)
+	}
+
 	var year, month, day, hour, minute, second int
 	var isRepeat, isHourRepeat, isDayRepeat, isWeekRepeat, isMonthRepeat bool
+	var isEveryNHours, isEveryNDays, isEveryNWeeks, isEveryNMonths bool
+	var everyN int
+	var pendingEveryN int
+	var havePendingEveryN bool
 	var dayOfWeek time.Weekday
 	var duration time.Duration
 
 	datePattern := regexp.MustCompile(`^([0-9]{2})?[0-9]{2}-[0-9]{1,2}-[0-9]{1,2}|[0-9]{1,2}-[0-9]{1,2}$`)
 	timePattern := regexp.MustCompile(`^[0-9]{1,2}:[0-9]{1,2}(:[0-9]{1,2})?$`)
 	minuteSecondPattern := regexp.MustCompile(`^[0-9]{1,2}(:[0-9]{1,2})?$`)
+	integerPattern := regexp.MustCompile(`^[0-9]+$`)
 	dayOfWeekPattern := regexp.MustCompile(`(?i)^sun[a-z]*|mon[a-z]*|tue[a-z]*|wed[a-z]*|thu[a-z]*|fri[a-z]*|sat[a-z]*$`)
 	dayOfMonthPattern := regexp.MustCompile(`(?i)^[0-9]{1,2}(st|nd|rd|th)$`)
 	durationPattern := regexp.MustCompile(`(?i)^~[0-9]+(h[a-z]*|m[a-z]*|s[a-z]*)$`)
@@ -295,38 +441,69 @@ func (self *Job) parsePlan(input string) (*Plan, error) {
 			}
 		case spec == "every": // repeat
 			isRepeat = true
+		case isRepeat && !havePendingEveryN && !isHourRepeat && !isDayRepeat && !isEveryNHours && !isEveryNDays && !isEveryNWeeks && !isEveryNMonths && integerPattern.MatchString(spec): // "every N ..."
+			n, err := strconv.Atoi(spec)
+			if err != nil {
+				return nil, err
+			}
+			if n <= 0 {
+				return nil, errors.New("every N must be positive")
+			}
+			pendingEveryN = n
+			havePendingEveryN = true
+		case havePendingEveryN && spec == "hours":
+			everyN = pendingEveryN
+			havePendingEveryN = false
+			isEveryNHours = true
+		case havePendingEveryN && spec == "days":
+			everyN = pendingEveryN
+			havePendingEveryN = false
+			isEveryNDays = true
+		case havePendingEveryN && spec == "weeks":
+			everyN = pendingEveryN
+			havePendingEveryN = false
+			isEveryNWeeks = true
+		case havePendingEveryN && spec == "months":
+			everyN = pendingEveryN
+			havePendingEveryN = false
+			isEveryNMonths = true
 		case spec == "hour" && isRepeat: // hour repeat
 			isHourRepeat = true
 		case spec == "day" && isRepeat: // day repeat
 			isDayRepeat = true
-		case isRepeat && dayOfWeekPattern.MatchString(spec):
+		case isEveryNWeeks && dayOfWeekPattern.MatchString(spec):
+			err := parseDayOfWeek(spec, &dayOfWeek)
+			if err != nil {
+				return nil, err
+			}
+		case isRepeat && !isEveryNWeeks && dayOfWeekPattern.MatchString(spec):
 			err := parseDayOfWeek(spec, &dayOfWeek)
 			if err != nil {
 				return nil, err
 			}
 			isWeekRepeat = true
-		case isWeekRepeat && timePattern.MatchString(spec):
+		case (isWeekRepeat || isEveryNWeeks) && timePattern.MatchString(spec):
 			err := parseTime(spec, &hour, &minute, &second)
 			if err != nil {
 				return nil, err
 			}
-		case isRepeat && dayOfMonthPattern.MatchString(spec):
+		case isRepeat && !isEveryNMonths && dayOfMonthPattern.MatchString(spec):
 			err := parseDayOfMonth(spec, &day)
 			if err != nil {
 				return nil, err
 			}
 			isMonthRepeat = true
-		case isMonthRepeat && timePattern.MatchString(spec):
+		case (isMonthRepeat || isEveryNMonths) && timePattern.MatchString(spec):
 			err := parseTime(spec, &hour, &minute, &second)
 			if err != nil {
 				return nil, err
 			}
-		case isHourRepeat && minuteSecondPattern.MatchString(spec):
+		case (isHourRepeat || isEveryNHours) && minuteSecondPattern.MatchString(spec):
 			err := parseMinuteSecond(spec, &minute, &second)
 			if err != nil {
 				return nil, err
 			}
-		case isDayRepeat && timePattern.MatchString(spec):
+		case (isDayRepeat || isEveryNDays) && timePattern.MatchString(spec):
 			err := parseTime(spec, &hour, &minute, &second)
 			if err != nil {
 				return nil, err
@@ -352,6 +529,14 @@ func (self *Job) parsePlan(input string) (*Plan, error) {
 		} else if time.Now().After(end) {
 			state = EXPIRED
 		}
+	} else if isEveryNHours {
+		start, state = self.nextEveryNHoursRepeat(everyN, duration, minute, second)
+	} else if isEveryNDays {
+		start, state = self.nextEveryNDaysRepeat(everyN, duration, hour, minute, second)
+	} else if isEveryNWeeks {
+		start, state = self.nextEveryNWeeksRepeat(everyN, duration, dayOfWeek, hour, minute, second)
+	} else if isEveryNMonths {
+		start, state = self.nextEveryNMonthsRepeat(everyN, duration, hour, minute, second)
 	} else if isHourRepeat {
 		start, state = self.nextHourRepeat(duration, minute, second)
 	} else if isDayRepeat {
@@ -363,10 +548,38 @@ func (self *Job) parsePlan(input string) (*Plan, error) {
 	} else {
 		return nil, errors.New("invalid time spec")
 	}
+
+	var catchupSlots []time.Time
+	if catchupLimit > 0 {
+		since := self.lastRun()
+		switch {
+		case isEveryNHours:
+			stride := time.Duration(everyN) * time.Hour
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, stride, t) })
+		case isEveryNDays:
+			stride := time.Duration(everyN) * 24 * time.Hour
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, stride, t) })
+		case isEveryNWeeks:
+			stride := time.Duration(everyN) * 7 * 24 * time.Hour
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, stride, t) })
+		case isEveryNMonths:
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return calendarStrideAfter(start, everyN, t) })
+		case isHourRepeat:
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, time.Hour, t) })
+		case isDayRepeat:
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, 24*time.Hour, t) })
+		case isWeekRepeat:
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return fixedStrideAfter(start, 7*24*time.Hour, t) })
+		case isMonthRepeat:
+			catchupSlots = self.catchupSlots(since, catchupLimit, func(t time.Time) time.Time { return calendarStrideAfter(start, 1, t) })
+		}
+	}
+
 	return &Plan{
-		Time:    start,
-		Comment: comment,
-		State:   state,
+		Time:         start,
+		Comment:      comment,
+		State:        state,
+		CatchupSlots: catchupSlots,
 	}, nil
 }
 
@@ -474,6 +687,292 @@ func parseDuration(spec string, duration *time.Duration) error {
 	return nil
 }
 
+// CronField holds the set of values a single cron field accepts, or marks
+// the field as unrestricted ("*").
+type CronField struct {
+	always bool
+	set    map[int]bool
+}
+
+func (self *CronField) Match(v int) bool {
+	if self.always {
+		return true
+	}
+	return self.set[v]
+}
+
+// CronSchedule is a parsed 5-field (minute hour day month weekday) or
+// 6-field (second minute hour day month weekday) cron expression.
+type CronSchedule struct {
+	Second  *CronField // nil when the expression has no seconds field
+	Minute  *CronField
+	Hour    *CronField
+	Day     *CronField
+	Month   *CronField
+	Weekday *CronField
+}
+
+var cronFieldPattern = regexp.MustCompile(`(?i)^[0-9a-z*,/-]+$`)
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// looksLikeCron reports whether specs is shaped like a 5 or 6 field cron
+// expression, so parsePlan can dispatch to the cron parser instead of the
+// "every ..." English grammar.
+func looksLikeCron(specs []string) bool {
+	if len(specs) != 5 && len(specs) != 6 {
+		return false
+	}
+	for _, spec := range specs {
+		if !cronFieldPattern.MatchString(spec) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCronSchedule(specs []string) (*CronSchedule, error) {
+	fields := specs
+	idx := 0
+	var secondField *CronField
+	if len(fields) == 6 {
+		f, err := parseCronField(fields[0], 0, 59, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		secondField = f
+		idx = 1
+	}
+	minuteField, err := parseCronField(fields[idx], 0, 59, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	hourField, err := parseCronField(fields[idx+1], 0, 23, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	dayField, err := parseCronField(fields[idx+2], 1, 31, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	monthField, err := parseCronField(fields[idx+3], 1, 12, cronMonthNames, nil)
+	if err != nil {
+		return nil, err
+	}
+	weekdayField, err := parseCronField(fields[idx+4], 0, 6, cronWeekdayNames, cronWeekdayAlias)
+	if err != nil {
+		return nil, err
+	}
+	return &CronSchedule{
+		Second:  secondField,
+		Minute:  minuteField,
+		Hour:    hourField,
+		Day:     dayField,
+		Month:   monthField,
+		Weekday: weekdayField,
+	}, nil
+}
+
+// cronWeekdayAlias maps cron's "7" spelling of Sunday onto time.Weekday's
+// 0, so `0 0 * * 7` matches the same days as `0 0 * * 0`.
+var cronWeekdayAlias = map[int]int{7: 0}
+
+// parseCronField parses a single cron field (e.g. "*", "1,15,30", "1-5",
+// "*/15", "0-30/5", or a named month/weekday) into a CronField. alias
+// remaps out-of-range spellings (e.g. weekday 7 -> 0) before the
+// min/max bounds are enforced; pass nil when a field has none.
+func parseCronField(spec string, min, max int, names map[string]int, alias map[int]int) (*CronField, error) {
+	if spec == "*" {
+		return &CronField{always: true}, nil
+	}
+	field := &CronField{set: make(map[int]bool)}
+	for _, part := range strings.Split(spec, ",") {
+		rangePart := part
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil {
+				return nil, errors.New("parse cron step")
+			}
+			if s <= 0 {
+				return nil, errors.New("cron step must be positive")
+			}
+			step = s
+		}
+		lo, hi := min, max
+		if rangePart != "*" {
+			if i := strings.Index(rangePart, "-"); i >= 0 {
+				// Range endpoints are expanded against the raw min/max
+				// (before alias remapping) so a range like weekday "5-7"
+				// still walks Fri, Sat, Sun instead of collapsing once
+				// "7" aliases down to "0".
+				rawMax := max
+				for k := range alias {
+					if k > rawMax {
+						rawMax = k
+					}
+				}
+				l, err := parseCronRangeValue(rangePart[:i], min, rawMax, names)
+				if err != nil {
+					return nil, err
+				}
+				h, err := parseCronRangeValue(rangePart[i+1:], min, rawMax, names)
+				if err != nil {
+					return nil, err
+				}
+				lo, hi = l, h
+			} else {
+				v, err := parseCronValue(rangePart, min, max, names, alias)
+				if err != nil {
+					return nil, err
+				}
+				lo, hi = v, v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			av := v
+			if a, ok := alias[v]; ok {
+				av = a
+			}
+			field.set[av] = true
+		}
+	}
+	return field, nil
+}
+
+func parseCronValue(spec string, min, max int, names map[string]int, alias map[int]int) (int, error) {
+	if names != nil {
+		if v, ok := names[strings.ToLower(spec)]; ok {
+			return v, nil
+		}
+	}
+	v, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, errors.New("parse cron field")
+	}
+	if a, ok := alias[v]; ok {
+		v = a
+	}
+	if v < min || v > max {
+		return 0, errors.New("cron field value out of range")
+	}
+	return v, nil
+}
+
+// parseCronRangeValue parses one endpoint of a "lo-hi" range without
+// applying an alias remap, so the range can still be expanded over its
+// original span before any alias folds a value back down.
+func parseCronRangeValue(spec string, min, max int, names map[string]int) (int, error) {
+	if names != nil {
+		if v, ok := names[strings.ToLower(spec)]; ok {
+			return v, nil
+		}
+	}
+	v, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, errors.New("parse cron field")
+	}
+	if v < min || v > max {
+		return 0, errors.New("cron field value out of range")
+	}
+	return v, nil
+}
+
+// dayMatches applies cron's day-of-month/day-of-week OR rule: when both
+// fields are restricted, a day matching either one is accepted.
+func (self *CronSchedule) dayMatches(t time.Time) bool {
+	if !self.Day.always && !self.Weekday.always {
+		return self.Day.Match(t.Day()) || self.Weekday.Match(int(t.Weekday()))
+	}
+	return self.Day.Match(t.Day()) && self.Weekday.Match(int(t.Weekday()))
+}
+
+func (self *CronSchedule) matches(t time.Time) bool {
+	if !self.Month.Match(int(t.Month())) {
+		return false
+	}
+	if !self.dayMatches(t) {
+		return false
+	}
+	if !self.Hour.Match(t.Hour()) {
+		return false
+	}
+	if !self.Minute.Match(t.Minute()) {
+		return false
+	}
+	if self.Second != nil && !self.Second.Match(t.Second()) {
+		return false
+	}
+	return true
+}
+
+// Next computes the next time strictly after "after" that satisfies the
+// schedule, advancing field by field: minutes roll into hours, hours into
+// days, days into months, skipping whole ranges whenever a coarser field
+// doesn't match.
+func (self *CronSchedule) Next(after time.Time) time.Time {
+	var t time.Time
+	if self.Second != nil {
+		t = after.Add(time.Second)
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	} else {
+		t = after.Add(time.Minute)
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	}
+	for i := 0; i < 5*366*24*60; i++ {
+		if !self.Month.Match(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, 1, 0)
+			continue
+		}
+		if !self.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1)
+			continue
+		}
+		if !self.Hour.Match(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(time.Hour)
+			continue
+		}
+		if !self.Minute.Match(t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location()).Add(time.Minute)
+			continue
+		}
+		if self.Second != nil && !self.Second.Match(t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return t
+}
+
+// nextCronRepeat evaluates a cron schedule against the current wall clock:
+// NOW when the current minute (or second, for 6-field expressions) matches
+// and hasn't already been logged, WAIT with the computed next fire time
+// otherwise.
+func (self *Job) nextCronRepeat(schedule *CronSchedule) (time.Time, int) {
+	now := time.Now()
+	var current, end time.Time
+	if schedule.Second != nil {
+		current = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.Local)
+		end = current.Add(time.Second)
+	} else {
+		current = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, time.Local)
+		end = current.Add(time.Minute)
+	}
+	if schedule.matches(current) && !self.hasLog(current, end) {
+		return current, NOW
+	}
+	return schedule.Next(now), WAIT
+}
+
 func (self *Job) hasLog(start time.Time, end time.Time) bool {
 	for _, t := range self.Log {
 		if t.After(start) && t.Before(end) || t == start || t == end {
@@ -562,3 +1061,661 @@ func (self *Job) nextMonthRepeat(duration time.Duration, day, hour, minute, seco
 	}
 	return t, WAIT
 }
+
+func (self *Job) nextYearRepeat(duration time.Duration, month time.Month, day, hour, minute, second int) (time.Time, int) {
+	if duration > time.Hour*24*365 {
+		duration = time.Hour * 24 * 365
+	}
+	y, _, _ := time.Now().Date()
+	t := time.Date(y, month, day, hour, minute, second, 0, time.Local)
+	tEnd := t.Add(duration)
+	if between(time.Now(), t, tEnd) && !self.hasLog(t, tEnd) {
+		return t, NOW
+	} else if time.Now().After(t) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t, WAIT
+}
+
+// parsePredefinedPlan handles the "@"-prefixed shorthands: @hourly,
+// @daily, @weekly, @monthly, @annually/@yearly, @reboot, and
+// @after <duration>. catchupLimit is the "catchup:" directive, if any;
+// it only applies to the repeating shorthands, since @reboot and @after
+// already fire at most once per daemon run.
+func (self *Job) parsePredefinedPlan(specs []string, comment string, catchupLimit int) (*Plan, error) {
+	switch specs[0] {
+	case "@hourly":
+		start, state := self.nextHourRepeat(0, 0, 0)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return fixedStrideAfter(start, time.Hour, t)
+			})
+		}
+		return &Plan{Time: start, Comment: comment, State: state, CatchupSlots: catchupSlots}, nil
+	case "@daily":
+		start, state := self.nextDayRepeat(0, 0, 0, 0)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return fixedStrideAfter(start, 24*time.Hour, t)
+			})
+		}
+		return &Plan{Time: start, Comment: comment, State: state, CatchupSlots: catchupSlots}, nil
+	case "@weekly":
+		start, state := self.nextWeekRepeat(0, time.Sunday, 0, 0, 0)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return fixedStrideAfter(start, 7*24*time.Hour, t)
+			})
+		}
+		return &Plan{Time: start, Comment: comment, State: state, CatchupSlots: catchupSlots}, nil
+	case "@monthly":
+		start, state := self.nextMonthRepeat(0, 1, 0, 0, 0)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return calendarStrideAfter(start, 1, t)
+			})
+		}
+		return &Plan{Time: start, Comment: comment, State: state, CatchupSlots: catchupSlots}, nil
+	case "@annually", "@yearly":
+		start, state := self.nextYearRepeat(0, time.January, 1, 0, 0, 0)
+		var catchupSlots []time.Time
+		if catchupLimit > 0 {
+			catchupSlots = self.catchupSlots(self.lastRun(), catchupLimit, func(t time.Time) time.Time {
+				return calendarStrideAfter(start, 12, t)
+			})
+		}
+		return &Plan{Time: start, Comment: comment, State: state, CatchupSlots: catchupSlots}, nil
+	case "@reboot":
+		if catchupLimit > 0 {
+			return nil, errors.New("catchup is not supported with @reboot")
+		}
+		return &Plan{Time: daemonStartTime, Comment: comment, State: NOW, Reboot: true}, nil
+	case "@after":
+		if catchupLimit > 0 {
+			return nil, errors.New("catchup is not supported with @after")
+		}
+		if len(specs) < 2 {
+			return nil, errors.New("missing @after duration")
+		}
+		offset, err := time.ParseDuration(specs[1])
+		if err != nil {
+			return nil, errors.New("parse @after duration")
+		}
+		start := daemonStartTime.Add(offset)
+		// @after fires once; dedupe against a log entry near its own slot
+		// time rather than "the job has ever logged anything" -- Job.Log is
+		// shared with any other plan on the same job (e.g. "@after 10m" +
+		// "and @hourly"), which would otherwise mark this EXPIRED as soon as
+		// the other plan logs a run of its own.
+		if self.hasLog(start.Add(-time.Minute), start.Add(time.Minute)) {
+			return &Plan{Time: start, Comment: comment, State: EXPIRED}, nil
+		}
+		state := WAIT
+		if time.Now().After(start) {
+			state = NOW
+		}
+		return &Plan{Time: start, Comment: comment, State: state}, nil
+	default:
+		return nil, errors.New("unknown predefined schedule: " + specs[0])
+	}
+}
+
+// anchor returns the stable epoch that "every N ..." recurrence is computed
+// from: the first entry in Job.Log, or the job file's mtime if it has never
+// logged a run. Anchoring here (instead of "now modulo N") keeps the
+// cadence stable across daemon restarts.
+func (self *Job) anchor() time.Time {
+	if len(self.Log) > 0 {
+		return self.Log[0]
+	}
+	return self.ModTime
+}
+
+func (self *Job) nextEveryNHoursRepeat(n int, duration time.Duration, minute, second int) (time.Time, int) {
+	stride := time.Duration(n) * time.Hour
+	if duration > stride {
+		duration = stride
+	}
+	anchor := self.anchor()
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), anchor.Hour(), minute, second, 0, anchor.Location())
+	now := time.Now()
+	strides := elapsedStrides(now.Sub(anchor), stride)
+	t := anchor.Add(stride * time.Duration(strides))
+	tEnd := t.Add(duration)
+	if between(now, t, tEnd) && !self.hasLog(t, tEnd) {
+		return t, NOW
+	} else if now.After(t) {
+		t = t.Add(stride)
+	}
+	return t, WAIT
+}
+
+func (self *Job) nextEveryNDaysRepeat(n int, duration time.Duration, hour, minute, second int) (time.Time, int) {
+	stride := time.Duration(n) * 24 * time.Hour
+	if duration > stride {
+		duration = stride
+	}
+	anchor := self.anchor()
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), hour, minute, second, 0, anchor.Location())
+	now := time.Now()
+	strides := elapsedStrides(now.Sub(anchor), stride)
+	t := anchor.Add(stride * time.Duration(strides))
+	tEnd := t.Add(duration)
+	if between(now, t, tEnd) && !self.hasLog(t, tEnd) {
+		return t, NOW
+	} else if now.After(t) {
+		t = t.Add(stride)
+	}
+	return t, WAIT
+}
+
+func (self *Job) nextEveryNWeeksRepeat(n int, duration time.Duration, dayOfWeek time.Weekday, hour, minute, second int) (time.Time, int) {
+	stride := time.Duration(n) * 7 * 24 * time.Hour
+	if duration > stride {
+		duration = stride
+	}
+	anchor := self.anchor()
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), hour, minute, second, 0, anchor.Location())
+	for anchor.Weekday() != dayOfWeek {
+		anchor = anchor.Add(24 * time.Hour)
+	}
+	now := time.Now()
+	strides := elapsedStrides(now.Sub(anchor), stride)
+	t := anchor.Add(stride * time.Duration(strides))
+	tEnd := t.Add(duration)
+	if between(now, t, tEnd) && !self.hasLog(t, tEnd) {
+		return t, NOW
+	} else if now.After(t) {
+		t = t.Add(stride)
+	}
+	return t, WAIT
+}
+
+func (self *Job) nextEveryNMonthsRepeat(n int, duration time.Duration, hour, minute, second int) (time.Time, int) {
+	maxDuration := time.Hour * 24 * 30 * time.Duration(n)
+	if duration > maxDuration {
+		duration = maxDuration
+	}
+	anchor := self.anchor()
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), hour, minute, second, 0, anchor.Location())
+	now := time.Now()
+	monthsElapsed := (now.Year()-anchor.Year())*12 + int(now.Month()-anchor.Month())
+	strides := monthsElapsed / n
+	if monthsElapsed%n != 0 {
+		strides++
+	}
+	if strides < 0 {
+		strides = 0
+	}
+	t := anchor.AddDate(0, strides*n, 0)
+	tEnd := t.Add(duration)
+	if between(now, t, tEnd) && !self.hasLog(t, tEnd) {
+		return t, NOW
+	} else if now.After(t) {
+		t = t.AddDate(0, n, 0)
+	}
+	return t, WAIT
+}
+
+// elapsedStrides returns how many multiples of stride fit into elapsed,
+// rounded up, clamped to non-negative.
+func elapsedStrides(elapsed, stride time.Duration) int {
+	strides := elapsed / stride
+	if elapsed%stride != 0 {
+		strides++
+	}
+	if strides < 0 {
+		strides = 0
+	}
+	return int(strides)
+}
+
+// parseWeeklyRange parses a "between" clause body, e.g. "mon-fri
+// 09:00-18:00", and records the resulting windows on the job for each
+// matching weekday.
+func (self *Job) parseWeeklyRange(spec string) error {
+	parts := strings.Fields(spec)
+	if len(parts) != 2 {
+		return errors.New("parse between clause")
+	}
+	days, err := parseWeekdayRangeList(parts[0])
+	if err != nil {
+		return err
+	}
+	tr, err := parseMinuteRange(parts[1])
+	if err != nil {
+		return err
+	}
+	for _, d := range days {
+		self.WeeklyRanges[d] = append(self.WeeklyRanges[d], tr)
+	}
+	return nil
+}
+
+// parseWeekdayRangeList parses a comma-separated list of weekdays or
+// weekday ranges, e.g. "mon-fri" or "mon,wed,fri".
+func parseWeekdayRangeList(spec string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(spec, ",") {
+		if i := strings.Index(part, "-"); i >= 0 {
+			var lo, hi time.Weekday
+			if err := parseDayOfWeek(part[:i], &lo); err != nil {
+				return nil, err
+			}
+			if err := parseDayOfWeek(part[i+1:], &hi); err != nil {
+				return nil, err
+			}
+			for d := lo; ; d = (d + 1) % 7 {
+				days = append(days, d)
+				if d == hi {
+					break
+				}
+			}
+		} else {
+			var d time.Weekday
+			if err := parseDayOfWeek(part, &d); err != nil {
+				return nil, err
+			}
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+// parseMinuteRange parses a "09:00-18:00" style time-of-day range into
+// minutes since midnight.
+func parseMinuteRange(spec string) (TimeRange, error) {
+	i := strings.Index(spec, "-")
+	if i < 0 {
+		return TimeRange{}, errors.New("parse time range")
+	}
+	after, err := parseMinuteOfDay(spec[:i])
+	if err != nil {
+		return TimeRange{}, err
+	}
+	before, err := parseMinuteOfDay(spec[i+1:])
+	if err != nil {
+		return TimeRange{}, err
+	}
+	if after > before {
+		return TimeRange{}, errors.New("between window must not wrap past midnight")
+	}
+	return TimeRange{After: after, Before: before}, nil
+}
+
+func parseMinuteOfDay(spec string) (int, error) {
+	var hour, minute, second int
+	if err := parseTime(spec, &hour, &minute, &second); err != nil {
+		return 0, err
+	}
+	return hour*60 + minute, nil
+}
+
+func (self *Job) hasWeeklyRanges() bool {
+	for _, ranges := range self.WeeklyRanges {
+		if len(ranges) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Job) inWeeklyRange(t time.Time) bool {
+	minuteOfDay := t.Hour()*60 + t.Minute()
+	for _, r := range self.WeeklyRanges[t.Weekday()] {
+		if minuteOfDay >= r.After && minuteOfDay <= r.Before {
+			return true
+		}
+	}
+	return false
+}
+
+// nextWeeklyRangeStart scans forward minute by minute, up to a week, for
+// the start of the next allowed window.
+func (self *Job) nextWeeklyRangeStart(after time.Time) time.Time {
+	t := after
+	for i := 0; i < 7*24*60; i++ {
+		if self.inWeeklyRange(t) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return t
+}
+
+// applyWeeklyRanges gates plans against the job's "between" allow-windows.
+// A plan that would otherwise fire NOW outside every configured window is
+// suppressed back to WAIT. A WAIT plan's fire time is clamped to the next
+// window too, so the timer checkJobs sets for it expires inside a window
+// (checkJobs re-checks the window when that timer fires and skips the run
+// if it falls outside).
+func (self *Job) applyWeeklyRanges() {
+	if !self.hasWeeklyRanges() {
+		return
+	}
+	now := time.Now()
+	for _, p := range self.Plans {
+		switch p.State {
+		case NOW:
+			if self.inWeeklyRange(now) {
+				continue
+			}
+			p.State = WAIT
+			p.Time = self.nextWeeklyRangeStart(now)
+		case WAIT:
+			if self.inWeeklyRange(p.Time) {
+				continue
+			}
+			p.Time = self.nextWeeklyRangeStart(p.Time)
+		}
+	}
+}
+
+// removeMatching returns specs with every element matching pattern
+// dropped, e.g. stripping a consumed "catchup:..." directive before the
+// remaining specs are dispatched to the cron or English-grammar parsers.
+func removeMatching(specs []string, pattern *regexp.Regexp) []string {
+	filtered := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		if !pattern.MatchString(spec) {
+			filtered = append(filtered, spec)
+		}
+	}
+	return filtered
+}
+
+// lastRun returns the most recent entry in Job.Log, or the job file's
+// mtime if it has never run, as the starting point to replay missed
+// occurrences from.
+func (self *Job) lastRun() time.Time {
+	if len(self.Log) > 0 {
+		return self.Log[len(self.Log)-1]
+	}
+	return self.ModTime
+}
+
+// fixedStrideAfter returns the smallest occurrence of a fixed-duration
+// period (anchored in phase at "start") that is strictly after t, whether
+// t is before or after start -- this lets catchupSlots walk the phase
+// backwards from a past "since" up to the present.
+func fixedStrideAfter(start time.Time, stride time.Duration, t time.Time) time.Time {
+	diff := t.Sub(start)
+	k := diff / stride
+	if diff%stride != 0 && diff < 0 {
+		k--
+	}
+	return start.Add(stride * (k + 1))
+}
+
+// calendarStrideAfter is the calendar-month analogue of fixedStrideAfter,
+// for periods (months/years) whose length varies with the calendar. Like
+// fixedStrideAfter it works for t before start, stepping the phase
+// backwards so past occurrences can be enumerated.
+func calendarStrideAfter(start time.Time, months int, t time.Time) time.Time {
+	next := start
+	if t.Before(start) {
+		for {
+			prev := next.AddDate(0, -months, 0)
+			if !prev.After(t) {
+				return next
+			}
+			next = prev
+		}
+	}
+	for !next.After(t) {
+		next = next.AddDate(0, months, 0)
+	}
+	return next
+}
+
+// catchupSlots walks forward from "since" using next (which returns the
+// next candidate occurrence strictly after its argument) up to time.Now(),
+// and returns at most limit of the most recent missed slots that aren't
+// already present in Job.Log -- older misses are dropped to avoid firing a
+// thundering herd of catch-up runs.
+func (self *Job) catchupSlots(since time.Time, limit int, next func(time.Time) time.Time) []time.Time {
+	now := time.Now()
+	var slots []time.Time
+	t := since
+	for {
+		t = next(t)
+		if !t.Before(now) {
+			break
+		}
+		if !self.hasLog(t, t.Add(time.Minute)) {
+			slots = append(slots, t)
+		}
+	}
+	if len(slots) > limit {
+		slots = slots[len(slots)-limit:]
+	}
+	return slots
+}
+
+// listenControlSocket opens the control socket at jobDir/control.sock,
+// removing any stale socket file left behind by a previous run.
+func listenControlSocket(jobDir string) (net.Listener, error) {
+	sockPath := filepath.Join(jobDir, "control.sock")
+	if err := os.Remove(sockPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return net.Listen("unix", sockPath)
+}
+
+// acceptControlConns feeds accepted connections to connCh, where
+// main/checkJobs' idle select picks them up.
+func acceptControlConns(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		connCh <- conn
+	}
+}
+
+// runClient implements the "sched" CLI: dial the daemon's control socket,
+// send the given command line, and copy the response to stdout.
+func runClient(args []string) int {
+	currentUser, err := user.Current()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 1
+	}
+	sockPath := filepath.Join(currentUser.HomeDir, ".sched", "control.sock")
+	conn, err := net.Dial("unix", sockPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error: cannot reach sched daemon:", err)
+		return 1
+	}
+	defer conn.Close()
+	fmt.Fprintln(conn, strings.Join(args, " "))
+	io.Copy(os.Stdout, conn)
+	return 0
+}
+
+// handleControlConn dispatches a single control-socket command: list,
+// reload, run <job>, disable <job>, enable <job>, or tail <job>.
+func handleControlConn(conn net.Conn, jobDir string) {
+	defer conn.Close()
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		fmt.Fprintln(conn, "Error: empty command")
+		return
+	}
+	switch fields[0] {
+	case "list":
+		listJobs(conn, jobDir)
+	case "reload":
+		requestReload()
+		fmt.Fprintln(conn, "OK")
+	case "run":
+		if len(fields) < 2 {
+			fmt.Fprintln(conn, "Error: run requires a job name")
+			return
+		}
+		runJobByName(conn, jobDir, fields[1])
+	case "disable":
+		if len(fields) < 2 {
+			fmt.Fprintln(conn, "Error: disable requires a job name")
+			return
+		}
+		setJobDisabled(conn, jobDir, fields[1], true)
+	case "enable":
+		if len(fields) < 2 {
+			fmt.Fprintln(conn, "Error: enable requires a job name")
+			return
+		}
+		setJobDisabled(conn, jobDir, fields[1], false)
+	case "tail":
+		if len(fields) < 2 {
+			fmt.Fprintln(conn, "Error: tail requires a job name")
+			return
+		}
+		tailJobLog(conn, jobDir, fields[1])
+	default:
+		fmt.Fprintf(conn, "Error: unknown command %q\n", fields[0])
+	}
+}
+
+// listJobs dumps every job's parsed plan, next-fire time, and last log
+// entry, one line per job.
+func listJobs(conn net.Conn, jobDir string) {
+	filepath.Walk(jobDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(path, ".log") || strings.HasSuffix(path, ".disabled") || strings.HasSuffix(path, ".sock") {
+			return nil
+		}
+		name := filepath.Base(path)
+		status := "enabled"
+		if isDisabled(path) {
+			status = "disabled"
+		}
+		job := &Job{Path: path, Plans: make([]*Plan, 0), Log: make([]time.Time, 0), ModTime: info.ModTime()}
+		if err := job.Parse(); err != nil {
+			fmt.Fprintf(conn, "%s\t%s\terror: %v\n", name, status, err)
+			return nil
+		}
+		next := "-"
+		var nextTime time.Time
+		plans := make([]string, 0, len(job.Plans))
+		for _, p := range job.Plans {
+			state := map[int]string{EXPIRED: "expired", NOW: "now", WAIT: "wait"}[p.State]
+			plans = append(plans, p.Time.Format(time.RFC822)+" "+state)
+			if p.State == WAIT && (nextTime.IsZero() || p.Time.Before(nextTime)) {
+				nextTime = p.Time
+				next = p.Time.Format(time.RFC822)
+			}
+		}
+		last := "-"
+		if len(job.Log) > 0 {
+			last = job.Log[len(job.Log)-1].Format(time.RFC822)
+		}
+		fmt.Fprintf(conn, "%s\t%s\tcmd=%s %v\tplans=%s\tnext=%s\tlast=%s\n", name, status, job.Cmd, job.Args, strings.Join(plans, ", "), next, last)
+		return nil
+	})
+}
+
+// runJobByName force-fires a job now, outside its schedule, and appends to
+// its log like a normal run.
+func runJobByName(conn net.Conn, jobDir, name string) {
+	path := filepath.Join(jobDir, name)
+	if _, err := os.Stat(path); err != nil {
+		fmt.Fprintf(conn, "Error: no such job %q\n", name)
+		return
+	}
+	job := &Job{Path: path, Plans: make([]*Plan, 0), Log: make([]time.Time, 0)}
+	if err := job.Parse(); err != nil {
+		fmt.Fprintf(conn, "Error: %v\n", err)
+		return
+	}
+	job.Run()
+	requestReload()
+	fmt.Fprintln(conn, "OK")
+}
+
+// setJobDisabled toggles a job's <name>.disabled sidecar file, which
+// checkJobs skips over when walking jobDir.
+func setJobDisabled(conn net.Conn, jobDir, name string, disabled bool) {
+	path := filepath.Join(jobDir, name)
+	if _, err := os.Stat(path); err != nil {
+		fmt.Fprintf(conn, "Error: no such job %q\n", name)
+		return
+	}
+	sidecar := path + ".disabled"
+	if disabled {
+		f, err := os.Create(sidecar)
+		if err != nil {
+			fmt.Fprintf(conn, "Error: %v\n", err)
+			return
+		}
+		f.Close()
+	} else if err := os.Remove(sidecar); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(conn, "Error: %v\n", err)
+		return
+	}
+	requestReload()
+	fmt.Fprintln(conn, "OK")
+}
+
+func isDisabled(path string) bool {
+	_, err := os.Stat(path + ".disabled")
+	return err == nil
+}
+
+// tailJobLog streams a job's .log file to conn: its existing contents,
+// then newly appended lines until the client disconnects.
+func tailJobLog(conn net.Conn, jobDir, name string) {
+	path := filepath.Join(jobDir, name) + ".log"
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(conn, "Error: %v\n", err)
+		return
+	}
+	defer f.Close()
+
+	// tail never expects input from the client; a goroutine blocked reading
+	// conn notices the disconnect (read error/EOF) that conn.Write alone
+	// wouldn't surface between log updates, so the loop below can exit
+	// instead of polling forever on a dead connection.
+	disconnected := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, conn)
+		close(disconnected)
+	}()
+
+	buf := make([]byte, 4096)
+	for {
+		select {
+		case <-disconnected:
+			return
+		default:
+		}
+		n, err := f.Read(buf)
+		if n > 0 {
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err == io.EOF {
+			select {
+			case <-disconnected:
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		} else if err != nil {
+			return
+		}
+	}
+}